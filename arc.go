@@ -0,0 +1,237 @@
+package lru
+
+import (
+	"sync"
+
+	"github.com/axiomzen/golang-lru/simplelru"
+)
+
+// ARCCache is a thread-safe fixed size Adaptive Replacement Cache (ARC), as
+// described in Megiddo & Modha, "ARC: A Self-Tuning, Low Overhead
+// Replacement Cache". ARC balances between recency (LRU) and frequency
+// (LFU) by tracking four lists - two of actual entries (t1, t2) and two of
+// ghost entries (b1, b2) - and adapts the target size p of t1 based on
+// which ghost list is seeing hits.
+type ARCCache struct {
+	size int // Size is the total capacity of the cache
+	p    int // P is the dynamic preference towards T1 or T2
+
+	t1 simplelru.LRUCache // T1 is the LRU for recently accessed items
+	b1 simplelru.LRUCache // B1 is the ghost LRU for entries recently evicted from T1
+	t2 simplelru.LRUCache // T2 is the LRU for frequently accessed items
+	b2 simplelru.LRUCache // B2 is the ghost LRU for entries recently evicted from T2
+
+	lock sync.Mutex
+}
+
+// NewARC creates an ARC cache of the given size.
+func NewARC(size int) (*ARCCache, error) {
+	if size <= 0 {
+		return nil, ErrInvalidSize
+	}
+
+	t1, err := simplelru.NewLRU(size, nil)
+	if err != nil {
+		return nil, err
+	}
+	b1, err := simplelru.NewLRU(size, nil)
+	if err != nil {
+		return nil, err
+	}
+	t2, err := simplelru.NewLRU(size, nil)
+	if err != nil {
+		return nil, err
+	}
+	b2, err := simplelru.NewLRU(size, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ARCCache{
+		size: size,
+		p:    0,
+		t1:   t1,
+		b1:   b1,
+		t2:   t2,
+		b2:   b2,
+	}
+	return c, nil
+}
+
+// Get looks up a key's value from the cache.
+func (c *ARCCache) Get(key Key) (value interface{}, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// A hit in T1 promotes the entry to T2, since it is now being
+	// accessed a second time.
+	if val, ok := c.t1.Peek(key); ok {
+		c.t1.Remove(key)
+		c.t2.Add(key, val)
+		return val, ok
+	}
+
+	// A hit in T2 simply refreshes its recency there.
+	if val, ok := c.t2.Get(key); ok {
+		return val, ok
+	}
+
+	return nil, false
+}
+
+// Add adds a value to the cache.
+func (c *ARCCache) Add(key Key, value interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Already cached in T1, move to T2.
+	if c.t1.Contains(key) {
+		c.t1.Remove(key)
+		c.t2.Add(key, value)
+		return
+	}
+
+	// Already cached in T2, refresh the value and recency.
+	if c.t2.Contains(key) {
+		c.t2.Add(key, value)
+		return
+	}
+
+	// A ghost hit in B1 means T1 is too small - grow p towards T1.
+	if c.b1.Contains(key) {
+		delta := 1
+		if b1Len, b2Len := c.b1.Len(), c.b2.Len(); b2Len > b1Len {
+			delta = b2Len / b1Len
+		}
+		c.p = min(c.p+delta, c.size)
+		c.replace(false)
+		c.b1.Remove(key)
+		c.t2.Add(key, value)
+		return
+	}
+
+	// A ghost hit in B2 means T2 is too small - shrink p towards T2.
+	if c.b2.Contains(key) {
+		delta := 1
+		if b1Len, b2Len := c.b1.Len(), c.b2.Len(); b1Len > b2Len {
+			delta = b1Len / b2Len
+		}
+		c.p = max(c.p-delta, 0)
+		c.replace(true)
+		c.b2.Remove(key)
+		c.t2.Add(key, value)
+		return
+	}
+
+	// Brand new key. If T1 and B1 together fill the cache, either drop
+	// B1's oldest ghost and replace an entry (T1 below full size), or drop
+	// T1's oldest entry outright. Otherwise, once the four lists together
+	// hold at least size entries, replace an entry (first dropping B2's oldest
+	// ghost if they hold 2*size). Then insert into T1.
+	if c.t1.Len()+c.b1.Len() == c.size {
+		if c.t1.Len() < c.size {
+			c.b1.RemoveOldest()
+			c.replace(false)
+		} else {
+			c.t1.RemoveOldest()
+		}
+	} else if total := c.t1.Len() + c.b1.Len() + c.t2.Len() + c.b2.Len(); total >= c.size {
+		if total == 2*c.size {
+			c.b2.RemoveOldest()
+		}
+		c.replace(false)
+	}
+	c.t1.Add(key, value)
+}
+
+// replace evicts a single entry from T1 or T2 into its ghost list,
+// following the adaptive preference p. recentGhostHit indicates the
+// caller is responding to a B2 hit, which biases the choice towards
+// evicting from T1 when T1 holds exactly p entries.
+func (c *ARCCache) replace(recentGhostHit bool) {
+	t1Len := c.t1.Len()
+	if t1Len > 0 && (t1Len > c.p || (t1Len == c.p && recentGhostHit)) {
+		k, v, ok := c.t1.RemoveOldest()
+		if ok {
+			c.b1.Add(k, v)
+		}
+		return
+	}
+	k, v, ok := c.t2.RemoveOldest()
+	if ok {
+		c.b2.Add(k, v)
+	}
+}
+
+// Contains checks if a key is in the cache, without updating recency or
+// frequency.
+func (c *ARCCache) Contains(key Key) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.t1.Contains(key) || c.t2.Contains(key)
+}
+
+// Peek returns the value associated with a key without updating recency
+// or frequency.
+func (c *ARCCache) Peek(key Key) (value interface{}, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if val, ok := c.t1.Peek(key); ok {
+		return val, ok
+	}
+	return c.t2.Peek(key)
+}
+
+// Remove removes the provided key from the cache.
+func (c *ARCCache) Remove(key Key) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.t1.Remove(key) {
+		return
+	}
+	if c.t2.Remove(key) {
+		return
+	}
+	if c.b1.Remove(key) {
+		return
+	}
+	c.b2.Remove(key)
+}
+
+// Purge is used to completely clear the cache.
+func (c *ARCCache) Purge() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.t1.Purge()
+	c.t2.Purge()
+	c.b1.Purge()
+	c.b2.Purge()
+}
+
+// Keys returns a slice of the keys in the cache.
+func (c *ARCCache) Keys() []interface{} {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append(c.t1.Keys(), c.t2.Keys()...)
+}
+
+// Len returns the number of items in the cache.
+func (c *ARCCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.t1.Len() + c.t2.Len()
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
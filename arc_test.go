@@ -0,0 +1,90 @@
+package lru
+
+import "testing"
+
+func TestARC_RandomOps(t *testing.T) {
+	size := 128
+	l, err := NewARC(size)
+	if err != nil {
+		t.Fatalf("NewARC: %v", err)
+	}
+
+	n := 200000
+	for i := 0; i < n; i++ {
+		key := i % 512
+		l.Add(key, key)
+		_, _ = l.Get(key)
+		if l.Len() > size {
+			t.Fatalf("bad: len %d > size %d", l.Len(), size)
+		}
+	}
+}
+
+func TestARC_Add(t *testing.T) {
+	l, err := NewARC(4)
+	if err != nil {
+		t.Fatalf("NewARC: %v", err)
+	}
+
+	l.Add(1, 1)
+	if !l.t1.Contains(1) {
+		t.Fatalf("should be in t1")
+	}
+
+	l.Add(1, 1)
+	if !l.t2.Contains(1) {
+		t.Fatalf("should be moved to t2")
+	}
+
+	l.Add(1, 2)
+	if v, _ := l.t2.Peek(1); v != 2 {
+		t.Fatalf("value should be updated")
+	}
+}
+
+func TestARC_Get(t *testing.T) {
+	l, err := NewARC(4)
+	if err != nil {
+		t.Fatalf("NewARC: %v", err)
+	}
+
+	if _, ok := l.Get(1); ok {
+		t.Fatalf("should be a miss")
+	}
+
+	l.Add(1, 1)
+	if v, ok := l.Get(1); !ok || v != 1 {
+		t.Fatalf("bad: %v %v", v, ok)
+	}
+	if !l.t2.Contains(1) {
+		t.Fatalf("should be promoted to t2 on second access")
+	}
+}
+
+func TestARC_Contains(t *testing.T) {
+	l, err := NewARC(4)
+	if err != nil {
+		t.Fatalf("NewARC: %v", err)
+	}
+
+	l.Add(1, 1)
+	if !l.Contains(1) {
+		t.Fatalf("should contain 1")
+	}
+	if l.Contains(2) {
+		t.Fatalf("should not contain 2")
+	}
+}
+
+func TestARC_Remove(t *testing.T) {
+	l, err := NewARC(4)
+	if err != nil {
+		t.Fatalf("NewARC: %v", err)
+	}
+
+	l.Add(1, 1)
+	l.Remove(1)
+	if l.Contains(1) {
+		t.Fatalf("should have been removed")
+	}
+}
@@ -0,0 +1,268 @@
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// expirableEntry is used to hold a value in the evictList, along with the
+// time at which it should be treated as expired. A zero expires means the
+// entry never expires.
+type expirableEntry struct {
+	key     Key
+	value   interface{}
+	expires time.Time
+}
+
+// ExpirableCache is a thread-safe fixed size LRU cache whose entries also
+// carry a time-to-live. Get treats an expired entry as a miss and evicts
+// it inline. An optional background janitor goroutine can additionally
+// sweep expired entries out of the cache between accesses.
+type ExpirableCache struct {
+	maxEntries int
+	defaultTTL time.Duration
+
+	evictList *list.List
+	items     map[interface{}]*list.Element
+
+	// OnEvicted optionally specificies a callback function to be
+	// executed when an entry is purged from the cache, whether due to
+	// size, explicit removal, or expiration.
+	OnEvicted func(key Key, value interface{})
+
+	lock sync.Mutex
+
+	sweepInterval time.Duration
+	closeCh       chan struct{}
+	closeOnce     sync.Once
+	wg            sync.WaitGroup
+}
+
+// sweepBatchSize bounds how many entries, expired or not, the janitor
+// visits while holding the lock before yielding it back to foreground
+// callers.
+const sweepBatchSize = 32
+
+// NewWithTTL creates an ExpirableCache of the given size, where entries
+// added via Add expire after defaultTTL (a zero or negative defaultTTL
+// means entries never expire unless added via AddWithTTL). If
+// sweepInterval is positive, a background goroutine wakes up on that
+// interval and removes expired entries even if they are never looked up
+// again; the goroutine is stopped by calling Close.
+func NewWithTTL(size int, defaultTTL time.Duration, sweepInterval time.Duration) (*ExpirableCache, error) {
+	if size < 0 {
+		return nil, ErrInvalidSize
+	}
+	c := &ExpirableCache{
+		maxEntries:    size,
+		defaultTTL:    defaultTTL,
+		evictList:     list.New(),
+		items:         make(map[interface{}]*list.Element, size),
+		sweepInterval: sweepInterval,
+		closeCh:       make(chan struct{}),
+	}
+	if sweepInterval > 0 {
+		c.wg.Add(1)
+		go c.runJanitor()
+	}
+	return c, nil
+}
+
+// Add adds a value to the cache using the cache's default TTL.
+func (c *ExpirableCache) Add(key Key, value interface{}) (evicted bool) {
+	return c.AddWithTTL(key, value, c.defaultTTL)
+}
+
+// AddWithTTL adds a value to the cache that expires after ttl. A zero or
+// negative ttl means the entry never expires.
+func (c *ExpirableCache) AddWithTTL(key Key, value interface{}, ttl time.Duration) (evicted bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+
+	// Check for existing item
+	if ent, ok := c.items[key]; ok {
+		c.evictList.MoveToFront(ent)
+		e := ent.Value.(*expirableEntry)
+		e.value = value
+		e.expires = expires
+		return false
+	}
+
+	// Add new item
+	ent := c.evictList.PushFront(&expirableEntry{key: key, value: value, expires: expires})
+	c.items[key] = ent
+
+	evict := c.maxEntries != 0 && c.evictList.Len() > c.maxEntries
+	if evict {
+		c.removeOldest()
+	}
+	return evict
+}
+
+// Get looks up a key's value from the cache. An expired entry is treated
+// as a miss and is evicted inline.
+func (c *ExpirableCache) Get(key Key) (value interface{}, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	ent, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := ent.Value.(*expirableEntry)
+	if c.expired(e) {
+		c.removeElement(ent)
+		return nil, false
+	}
+	c.evictList.MoveToFront(ent)
+	return e.value, true
+}
+
+// Remove removes the provided key from the cache.
+func (c *ExpirableCache) Remove(key Key) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if ent, ok := c.items[key]; ok {
+		c.removeElement(ent)
+	}
+}
+
+// Purge is used to completely clear the cache.
+func (c *ExpirableCache) Purge() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.evictList = list.New()
+	c.items = make(map[interface{}]*list.Element, c.maxEntries)
+}
+
+// Keys returns a slice of the non-expired keys in the cache.
+func (c *ExpirableCache) Keys() []interface{} {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	keys := make([]interface{}, 0, len(c.items))
+	now := time.Now()
+	for ent := c.evictList.Front(); ent != nil; ent = ent.Next() {
+		e := ent.Value.(*expirableEntry)
+		if !e.expires.IsZero() && now.After(e.expires) {
+			continue
+		}
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+// Len returns the number of items in the cache, including any not-yet-swept
+// expired entries.
+func (c *ExpirableCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.evictList.Len()
+}
+
+// Close stops the background janitor goroutine, if one was started. It is
+// safe to call Close more than once.
+func (c *ExpirableCache) Close() {
+	c.closeOnce.Do(func() {
+		close(c.closeCh)
+	})
+	c.wg.Wait()
+}
+
+// expired reports whether e has a non-zero expiry in the past.
+func (c *ExpirableCache) expired(e *expirableEntry) bool {
+	return !e.expires.IsZero() && time.Now().After(e.expires)
+}
+
+// removeOldest removes the oldest item from the cache.
+func (c *ExpirableCache) removeOldest() {
+	ent := c.evictList.Back()
+	if ent != nil {
+		c.removeElement(ent)
+	}
+}
+
+// removeElement is used to remove a given list element from the cache.
+func (c *ExpirableCache) removeElement(e *list.Element) {
+	c.evictList.Remove(e)
+	kv := e.Value.(*expirableEntry)
+	delete(c.items, kv.key)
+	if c.OnEvicted != nil {
+		c.OnEvicted(kv.key, kv.value)
+	}
+}
+
+// runJanitor periodically sweeps expired entries out of the cache until
+// Close is called.
+func (c *ExpirableCache) runJanitor() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(c.sweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.sweep()
+		case <-c.closeCh:
+			return
+		}
+	}
+}
+
+// sweep walks the eviction list from the back, removing expired entries
+// in small batches so the write lock is never held for the whole list at
+// once, regardless of how many (if any) of the visited entries are
+// actually expired. Between batches the lock is released, so the janitor
+// never resumes from a raw *list.Element directly: that element may have
+// been removed (explicit Remove, size-based eviction, or Purge) while the
+// lock was free, and a detached list.Element can no longer be trusted to
+// report its true neighbours. Instead it remembers the next element's
+// key and re-looks it up through c.items after re-locking; if the key no
+// longer maps to a live element the sweep simply stops early and lets the
+// next tick start over from the back, rather than risk touching stale
+// state. Re-adding a key while the janitor is mid-sweep updates that
+// key's existing list element in place, so a resumed sweep picks up the
+// (possibly relocated, possibly refreshed) element safely.
+func (c *ExpirableCache) sweep() {
+	var nextKey Key
+	haveNext := false
+	for {
+		c.lock.Lock()
+		var e *list.Element
+		if haveNext {
+			el, ok := c.items[nextKey]
+			if !ok {
+				c.lock.Unlock()
+				return
+			}
+			e = el
+		} else {
+			e = c.evictList.Back()
+		}
+
+		visited := 0
+		for e != nil && visited < sweepBatchSize {
+			prev := e.Prev()
+			ent := e.Value.(*expirableEntry)
+			if c.expired(ent) {
+				c.removeElement(e)
+			}
+			e = prev
+			visited++
+		}
+
+		if e == nil {
+			c.lock.Unlock()
+			return
+		}
+		nextKey = e.Value.(*expirableEntry).key
+		haveNext = true
+		c.lock.Unlock()
+	}
+}
@@ -0,0 +1,132 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpirableCache_GetExpiresInline(t *testing.T) {
+	c, err := NewWithTTL(10, 10*time.Millisecond, 0)
+	if err != nil {
+		t.Fatalf("NewWithTTL: %v", err)
+	}
+	defer c.Close()
+
+	var evicted []Key
+	c.OnEvicted = func(key Key, value interface{}) {
+		evicted = append(evicted, key)
+	}
+
+	c.Add("a", 1)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = %v, %v, want 1, true", v, ok)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("a should have expired")
+	}
+	if c.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0 after inline expiry", c.Len())
+	}
+	if len(evicted) != 1 || evicted[0] != "a" {
+		t.Fatalf("OnEvicted = %v, want [a]", evicted)
+	}
+}
+
+func TestExpirableCache_NoTTLNeverExpires(t *testing.T) {
+	c, err := NewWithTTL(10, 0, 0)
+	if err != nil {
+		t.Fatalf("NewWithTTL: %v", err)
+	}
+	defer c.Close()
+
+	c.Add("a", 1)
+	time.Sleep(10 * time.Millisecond)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = %v, %v, want 1, true", v, ok)
+	}
+}
+
+func TestExpirableCache_JanitorSweeps(t *testing.T) {
+	c, err := NewWithTTL(10, 10*time.Millisecond, 5*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewWithTTL: %v", err)
+	}
+	defer c.Close()
+
+	c.AddWithTTL("a", 1, 5*time.Millisecond)
+
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		c.lock.Lock()
+		n := c.evictList.Len()
+		c.lock.Unlock()
+		if n == 0 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("janitor never swept the expired entry")
+}
+
+func TestExpirableCache_SweepSurvivesConcurrentRemoval(t *testing.T) {
+	c, err := NewWithTTL(2*sweepBatchSize, time.Hour, time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewWithTTL: %v", err)
+	}
+	defer c.Close()
+
+	// A list bigger than one sweep batch, with nothing expired, forces the
+	// janitor to release the lock mid-walk and resume on its next pass.
+	for i := 0; i < 2*sweepBatchSize; i++ {
+		c.Add(i, i)
+	}
+
+	// Hammer Remove/Add concurrently with the janitor so that, if it ever
+	// resumed from a stale *list.Element instead of re-validating the key
+	// through c.items, this would corrupt state for a re-added key.
+	deadline := time.Now().Add(100 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		for i := 0; i < 2*sweepBatchSize; i++ {
+			c.Remove(i)
+			c.Add(i, i)
+		}
+	}
+
+	for i := 0; i < 2*sweepBatchSize; i++ {
+		if v, ok := c.Get(i); !ok || v != i {
+			t.Fatalf("Get(%d) = %v, %v, want %d, true", i, v, ok, i)
+		}
+	}
+}
+
+func TestExpirableCache_ReAddDuringSweep(t *testing.T) {
+	c, err := NewWithTTL(10, 0, 2*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewWithTTL: %v", err)
+	}
+	defer c.Close()
+
+	c.AddWithTTL("a", 1, 3*time.Millisecond)
+
+	// Repeatedly refresh the TTL while the janitor is sweeping in the
+	// background; the entry must never be observed as expired.
+	deadline := time.Now().Add(100 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		c.AddWithTTL("a", 1, 20*time.Millisecond)
+		if _, ok := c.Get("a"); !ok {
+			t.Fatalf("a should not have been swept while its TTL was being refreshed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestExpirableCache_CloseIdempotent(t *testing.T) {
+	c, err := NewWithTTL(10, time.Millisecond, time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewWithTTL: %v", err)
+	}
+	c.Close()
+	c.Close()
+}
@@ -4,10 +4,10 @@
 package lru
 
 import (
-	"container/list"
-	//"errors"
 	"fmt"
 	"sync"
+
+	"github.com/axiomzen/golang-lru/simplelru"
 )
 
 var (
@@ -17,11 +17,21 @@ var (
 //ErrCacheMiss = fmt.Errorf("item not found")
 )
 
-// Cache is a thread-safe fixed size LRU cache.
+// A Key may be any value that is comparable. See http://golang.org/ref/spec#Comparison_operators
+type Key interface{}
+
+// unboundedSize backs a Cache constructed with maxEntries == 0 ("no
+// limit") on top of simplelru.LRU, which otherwise requires a positive
+// size.
+const unboundedSize = 1 << 62
+
+// Cache is a thread-safe fixed size LRU cache. It satisfies
+// simplelru.LRUCache by delegating all of its list/map bookkeeping to a
+// simplelru.LRU guarded by its own lock, rather than duplicating the
+// logic that TwoQueueCache and ARCCache also build on top of.
 type Cache struct {
 	maxEntries int
-	evictList  *list.List
-	items      map[interface{}]*list.Element
+	lru        *simplelru.LRU
 
 	// OnEvicted optionally specificies a callback function to be
 	// executed when an entry is purged from the cache.
@@ -32,118 +42,110 @@ type Cache struct {
 	lock sync.RWMutex
 }
 
-// A Key may be any value that is comparable. See http://golang.org/ref/spec#Comparison_operators
-type Key interface{}
+var _ simplelru.LRUCache = (*Cache)(nil)
 
-// entry is used to hold a value in the evictList
-type entry struct {
-	key   Key
-	value interface{}
-}
-
-// New creates an LRU of the given size
+// New creates an LRU of the given size. A size of 0 means no limit.
 func New(size int) (*Cache, error) {
 	if size < 0 {
 		return nil, ErrInvalidSize
 	}
 	c := &Cache{
 		maxEntries: size,
-		evictList:  list.New(),
-		items:      make(map[interface{}]*list.Element, size),
 	}
+	l, err := simplelru.NewLRU(innerSize(size), func(key simplelru.Key, value interface{}) {
+		if c.OnEvicted != nil {
+			c.OnEvicted(key, value)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.lru = l
 	return c, nil
 }
 
+// innerSize maps Cache's "0 means unlimited" convention onto the positive
+// size simplelru.LRU requires.
+func innerSize(maxEntries int) int {
+	if maxEntries == 0 {
+		return unboundedSize
+	}
+	return maxEntries
+}
+
 // Purge is used to completely clear the cache
 func (c *Cache) Purge() {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	c.evictList = list.New()
-	c.items = make(map[interface{}]*list.Element, c.maxEntries)
+	c.lru.Purge()
 }
 
-// Add adds a value to the cache.
-func (c *Cache) Add(key Key, value interface{}) {
+// Add adds a value to the cache. Returns true if an eviction occurred to
+// make room for it.
+//
+// The key parameter is typed interface{} rather than Key so that *Cache
+// satisfies simplelru.LRUCache exactly; Key is a distinct empty interface
+// type, so any Key value can still be passed here unchanged.
+func (c *Cache) Add(key interface{}, value interface{}) (evicted bool) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
+	return c.lru.Add(key, value)
+}
 
-	// Check for existing item
-	if ent, ok := c.items[key]; ok {
-		c.evictList.MoveToFront(ent)
-		ent.Value.(*entry).value = value
-		return
-	}
+// Get looks up a key's value from the cache.
+func (c *Cache) Get(key interface{}) (value interface{}, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Get(key)
+}
 
-	// Add new item
-	entry := c.evictList.PushFront(&entry{key, value})
-	c.items[key] = entry
+// Contains checks if a key is in the cache, without updating the
+// recent-ness.
+func (c *Cache) Contains(key interface{}) (ok bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.lru.Contains(key)
+}
 
-	// Verify size not exceeded
-	if c.maxEntries != 0 && c.evictList.Len() > c.maxEntries {
-		c.removeOldest()
-	}
+// Peek returns the key's value without updating the "recently used"-ness
+// of the key.
+func (c *Cache) Peek(key interface{}) (value interface{}, ok bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.lru.Peek(key)
 }
 
-// Get looks up a key's value from the cache.
-func (c *Cache) Get(key Key) (value interface{}, ok bool) {
+// Resize changes the cache size, evicting the oldest entries if the new
+// size is smaller than the current number of entries. It returns the
+// number of entries evicted. A newSize of 0 removes the limit entirely.
+func (c *Cache) Resize(newSize int) (evictedCount int) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	if ent, ok := c.items[key]; ok {
-		c.evictList.MoveToFront(ent)
-		return ent.Value.(*entry).value, true
-	}
-	return
+	c.maxEntries = newSize
+	return c.lru.Resize(innerSize(newSize))
 }
 
-// Remove removes the provided key from the cache.
-func (c *Cache) Remove(key Key) {
+// Remove removes the provided key from the cache, returning true if the
+// key was present.
+func (c *Cache) Remove(key interface{}) (present bool) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-
-	if ent, ok := c.items[key]; ok {
-		c.removeElement(ent)
-	}
+	return c.lru.Remove(key)
 }
 
 // RemoveOldest removes the oldest item from the cache.
-func (c *Cache) RemoveOldest() {
+func (c *Cache) RemoveOldest() (key, value interface{}, ok bool) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	c.removeOldest()
+	return c.lru.RemoveOldest()
 }
 
-// Keys returns a slice of the keys in the cache.
+// Keys returns a slice of the keys in the cache, from oldest to newest.
 func (c *Cache) Keys() []interface{} {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-
-	keys := make([]interface{}, len(c.items))
-	i := 0
-	for k := range c.items {
-		keys[i] = k
-		i++
-	}
-
-	return keys
-}
-
-// removeOldest removes the oldest item from the cache.
-func (c *Cache) removeOldest() {
-	ent := c.evictList.Back()
-	if ent != nil {
-		c.removeElement(ent)
-	}
-}
-
-// removeElement is used to remove a given list element from the cache
-func (c *Cache) removeElement(e *list.Element) {
-	c.evictList.Remove(e)
-	kv := e.Value.(*entry)
-	delete(c.items, kv.key)
-	if c.OnEvicted != nil {
-		c.OnEvicted(kv.key, kv.value)
-	}
+	return c.lru.Keys()
 }
 
 // Len returns the number of items in the cache.
@@ -152,5 +154,5 @@ func (c *Cache) Len() int {
 	defer c.lock.RUnlock()
 	//c.lock.Lock()
 	//defer c.lock.Unlock()
-	return c.evictList.Len()
+	return c.lru.Len()
 }
@@ -0,0 +1,214 @@
+package lru
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/axiomzen/golang-lru/simplelru"
+)
+
+const (
+	// Default2QRecentRatio is the ratio of the cache size used for the
+	// recently accessed cache (A1 in) when using New2Q.
+	Default2QRecentRatio = 0.25
+
+	// Default2QGhostEntries is the default ratio of ghost entries kept to
+	// track entries recently evicted from the recent list.
+	Default2QGhostEntries = 0.50
+)
+
+// TwoQueueCache is a thread-safe fixed size 2Q cache, as described in:
+// Jiang, Zhu: "2Q: A Low Overhead High Performance Buffer Management
+// Replacement Algorithm". It tracks frequently and recently accessed
+// entries separately to avoid cache pollution from brief bursts of access
+// to otherwise cold keys.
+type TwoQueueCache struct {
+	size        int
+	recentSize  int
+	recentRatio float64
+	ghostRatio  float64
+
+	recent      simplelru.LRUCache
+	frequent    simplelru.LRUCache
+	recentEvict simplelru.LRUCache
+
+	lock sync.Mutex
+}
+
+// New2Q creates a new TwoQueueCache using the default recent/ghost ratios.
+func New2Q(size int) (*TwoQueueCache, error) {
+	return New2QParams(size, Default2QRecentRatio, Default2QGhostEntries)
+}
+
+// New2QParams creates a new TwoQueueCache using the provided recent and
+// ghost ratios. recentRatio is the ratio of the cache size used for the
+// recently-accessed list, and ghostRatio is the ratio of the cache size
+// used for the recently-evicted (ghost) list.
+func New2QParams(size int, recentRatio, ghostRatio float64) (*TwoQueueCache, error) {
+	if size <= 0 {
+		return nil, ErrInvalidSize
+	}
+	if recentRatio < 0.0 || recentRatio > 1.0 {
+		return nil, fmt.Errorf("invalid recent ratio")
+	}
+	if ghostRatio < 0.0 || ghostRatio > 1.0 {
+		return nil, fmt.Errorf("invalid ghost ratio")
+	}
+
+	recentSize := int(float64(size) * recentRatio)
+	evictSize := int(float64(size) * ghostRatio)
+
+	recent, err := simplelru.NewLRU(size, nil)
+	if err != nil {
+		return nil, err
+	}
+	frequent, err := simplelru.NewLRU(size, nil)
+	if err != nil {
+		return nil, err
+	}
+	recentEvict, err := simplelru.NewLRU(evictSize, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &TwoQueueCache{
+		size:        size,
+		recentSize:  recentSize,
+		recentRatio: recentRatio,
+		ghostRatio:  ghostRatio,
+		recent:      recent,
+		frequent:    frequent,
+		recentEvict: recentEvict,
+	}
+	return c, nil
+}
+
+// Get looks up a key's value from the cache.
+func (c *TwoQueueCache) Get(key Key) (value interface{}, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// A hit in the frequent list stays in the frequent list.
+	if val, ok := c.frequent.Get(key); ok {
+		return val, ok
+	}
+
+	// A hit in the recent list is promoted to frequent, since it has now
+	// been accessed more than once.
+	if val, ok := c.recent.Peek(key); ok {
+		c.recent.Remove(key)
+		c.frequent.Add(key, val)
+		return val, ok
+	}
+
+	return nil, false
+}
+
+// Add adds a value to the cache.
+func (c *TwoQueueCache) Add(key Key, value interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Already in frequent, just update the value.
+	if c.frequent.Contains(key) {
+		c.frequent.Add(key, value)
+		return
+	}
+
+	// Already in recent, this is a second access so promote to frequent.
+	if c.recent.Contains(key) {
+		c.recent.Remove(key)
+		c.frequent.Add(key, value)
+		return
+	}
+
+	// If the key was recently evicted from the recent list, place it
+	// directly in frequent since it's being re-accessed.
+	if c.recentEvict.Contains(key) {
+		c.ensureSpace(true)
+		c.recentEvict.Remove(key)
+		c.frequent.Add(key, value)
+		return
+	}
+
+	// New key, goes in the recent list.
+	c.ensureSpace(false)
+	c.recent.Add(key, value)
+}
+
+// ensureSpace evicts from the appropriate list to make room for a new
+// entry. recentEvicted indicates the new entry is headed for the
+// frequent list as a result of a ghost hit.
+func (c *TwoQueueCache) ensureSpace(recentEvicted bool) {
+	recentLen := c.recent.Len()
+	freqLen := c.frequent.Len()
+	if recentLen+freqLen < c.size {
+		return
+	}
+
+	// Evict from the recent list if it is above its target size, or at its
+	// target size and the new entry is not a ghost hit; otherwise evict
+	// from frequent.
+	if recentLen > 0 && (recentLen > c.recentSize || (recentLen == c.recentSize && !recentEvicted)) {
+		k, _, ok := c.recent.RemoveOldest()
+		if ok {
+			c.recentEvict.Add(k, nil)
+		}
+		return
+	}
+
+	c.frequent.RemoveOldest()
+}
+
+// Contains checks if a key is in the cache, without updating recency.
+func (c *TwoQueueCache) Contains(key Key) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.frequent.Contains(key) || c.recent.Contains(key)
+}
+
+// Peek returns the value associated with a key without updating recency.
+func (c *TwoQueueCache) Peek(key Key) (value interface{}, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if val, ok := c.frequent.Peek(key); ok {
+		return val, ok
+	}
+	return c.recent.Peek(key)
+}
+
+// Remove removes the provided key from the cache.
+func (c *TwoQueueCache) Remove(key Key) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.frequent.Remove(key) {
+		return
+	}
+	if c.recent.Remove(key) {
+		return
+	}
+	c.recentEvict.Remove(key)
+}
+
+// Purge is used to completely clear the cache.
+func (c *TwoQueueCache) Purge() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.recent.Purge()
+	c.frequent.Purge()
+	c.recentEvict.Purge()
+}
+
+// Keys returns a slice of the keys in the cache.
+func (c *TwoQueueCache) Keys() []interface{} {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append(c.recent.Keys(), c.frequent.Keys()...)
+}
+
+// Len returns the number of items in the cache.
+func (c *TwoQueueCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.recent.Len() + c.frequent.Len()
+}
@@ -0,0 +1,130 @@
+package lru
+
+import "testing"
+
+func Test2Q_RandomOps(t *testing.T) {
+	size := 128
+	l, err := New2Q(size)
+	if err != nil {
+		t.Fatalf("New2Q: %v", err)
+	}
+
+	n := 200000
+	for i := 0; i < n; i++ {
+		key := i % 512
+		l.Add(key, key)
+		_, _ = l.Get(key)
+		if l.Len() > size {
+			t.Fatalf("bad: len %d > size %d", l.Len(), size)
+		}
+	}
+}
+
+func Test2Q_Get_RecentToFrequent(t *testing.T) {
+	l, err := New2Q(128)
+	if err != nil {
+		t.Fatalf("New2Q: %v", err)
+	}
+
+	// Fresh entry lives in the recent list.
+	l.Add(1, 1)
+	if _, ok := l.frequent.Peek(1); ok {
+		t.Fatalf("1 should not be in the frequent list yet")
+	}
+
+	// A Get on that entry promotes it to the frequent list.
+	val, ok := l.Get(1)
+	if !ok || val != 1 {
+		t.Fatalf("bad: %v %v", val, ok)
+	}
+	if !l.frequent.Contains(1) {
+		t.Fatalf("1 should have been promoted to frequent")
+	}
+}
+
+func Test2Q_Add_RecentToFrequent(t *testing.T) {
+	l, err := New2Q(128)
+	if err != nil {
+		t.Fatalf("New2Q: %v", err)
+	}
+
+	l.Add(1, 1)
+	if !l.recent.Contains(1) {
+		t.Fatalf("should be in recent")
+	}
+
+	l.Add(1, 1)
+	if !l.frequent.Contains(1) {
+		t.Fatalf("should be in frequent")
+	}
+}
+
+func Test2Q_Add_RecentEvict(t *testing.T) {
+	l, err := New2Q(4)
+	if err != nil {
+		t.Fatalf("New2Q: %v", err)
+	}
+
+	l.Add(1, 1)
+	l.Add(2, 2)
+	l.Add(3, 3)
+	l.Add(4, 4)
+	l.Add(5, 5)
+	if l.recentEvict.Len() != 1 {
+		t.Fatalf("should have gone to ghost list")
+	}
+	if !l.recentEvict.Contains(1) {
+		t.Fatalf("1 should be the ghost entry")
+	}
+
+	// Re-access of a ghost entry should bring it directly into frequent.
+	l.Add(1, 1)
+	if !l.frequent.Contains(1) {
+		t.Fatalf("should be in frequent")
+	}
+	if l.recentEvict.Contains(1) {
+		t.Fatalf("should no longer be a ghost entry")
+	}
+}
+
+func Test2Q_Contains(t *testing.T) {
+	l, err := New2Q(4)
+	if err != nil {
+		t.Fatalf("New2Q: %v", err)
+	}
+
+	l.Add(1, 1)
+	l.Add(2, 2)
+	l.Add(3, 3)
+	l.Add(4, 4)
+	if !l.Contains(1) {
+		t.Fatalf("1 should be contained")
+	}
+
+	// Contains must not protect 1 from eviction the way a Get would.
+	l.Add(5, 5)
+	if l.Contains(1) {
+		t.Fatalf("Contains should not have updated recent-ness of 1")
+	}
+}
+
+func Test2Q_Peek(t *testing.T) {
+	l, err := New2Q(4)
+	if err != nil {
+		t.Fatalf("New2Q: %v", err)
+	}
+
+	l.Add(1, 1)
+	l.Add(2, 2)
+	l.Add(3, 3)
+	l.Add(4, 4)
+	if v, ok := l.Peek(1); !ok || v != 1 {
+		t.Errorf("1 should be set to 1: %v, %v", v, ok)
+	}
+
+	// Peek must not protect 1 from eviction the way a Get would.
+	l.Add(5, 5)
+	if l.Contains(1) {
+		t.Errorf("Peek should not have updated recent-ness of 1")
+	}
+}
@@ -0,0 +1,77 @@
+package lru
+
+import "testing"
+
+func TestCache_Add_Evicted(t *testing.T) {
+	c, err := New(1)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if c.Add("a", 1) {
+		t.Fatalf("should not have evicted")
+	}
+	if !c.Add("b", 2) {
+		t.Fatalf("should have evicted")
+	}
+}
+
+func TestCache_Contains(t *testing.T) {
+	c, err := New(2)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	if !c.Contains("a") {
+		t.Fatalf("a should be contained")
+	}
+
+	c.Add("c", 3)
+	if c.Contains("a") {
+		t.Fatalf("Contains should not have updated recent-ness of a")
+	}
+}
+
+func TestCache_Peek(t *testing.T) {
+	c, err := New(2)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	if v, ok := c.Peek("a"); !ok || v != 1 {
+		t.Fatalf("a should be set to 1: %v, %v", v, ok)
+	}
+
+	c.Add("c", 3)
+	if c.Contains("a") {
+		t.Fatalf("Peek should not have updated recent-ness of a")
+	}
+}
+
+func TestCache_Resize(t *testing.T) {
+	c, err := New(2)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	if evicted := c.Resize(1); evicted != 1 {
+		t.Fatalf("expected 1 eviction, got %d", evicted)
+	}
+	if c.Contains("a") {
+		t.Fatalf("a should have been evicted")
+	}
+
+	if evicted := c.Resize(2); evicted != 0 {
+		t.Fatalf("expected no evictions, got %d", evicted)
+	}
+	c.Add("c", 3)
+	if !c.Contains("b") || !c.Contains("c") {
+		t.Fatalf("cache should hold 2 entries")
+	}
+}
@@ -0,0 +1,161 @@
+package lru
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// ShardedCache spreads its entries across a power-of-two number of
+// independent Cache shards, each with its own lock, so that concurrent
+// readers and writers hashing to different shards never contend on the
+// same mutex. This trades a small amount of LRU precision (eviction is
+// only exact within a shard, not across the whole cache) for much better
+// throughput under concurrent DNS/HTTP-style workloads.
+type ShardedCache struct {
+	shards []*Cache
+	mask   uint64
+	hasher func(Key) uint64
+}
+
+// NewSharded creates a ShardedCache with the given number of shards, each
+// sized to hold sizePerShard entries. shards must be a power of two. If
+// hasher is nil, a default hasher is used that fast-paths strings, fixed
+// size byte arrays, and integer types, falling back to an FNV-1a hash of
+// fmt.Sprintf("%v", key) for everything else.
+func NewSharded(shards, sizePerShard int, hasher func(Key) uint64) (*ShardedCache, error) {
+	if shards <= 0 || shards&(shards-1) != 0 {
+		return nil, fmt.Errorf("shards must be a power of two, got %d", shards)
+	}
+	if hasher == nil {
+		hasher = defaultHasher
+	}
+
+	cs := make([]*Cache, shards)
+	for i := range cs {
+		c, err := New(sizePerShard)
+		if err != nil {
+			return nil, err
+		}
+		cs[i] = c
+	}
+
+	return &ShardedCache{
+		shards: cs,
+		mask:   uint64(shards - 1),
+		hasher: hasher,
+	}, nil
+}
+
+// shardFor returns the shard responsible for key.
+func (s *ShardedCache) shardFor(key Key) *Cache {
+	return s.shards[s.hasher(key)&s.mask]
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred in
+// the shard holding key.
+func (s *ShardedCache) Add(key Key, value interface{}) (evicted bool) {
+	return s.shardFor(key).Add(key, value)
+}
+
+// Get looks up a key's value from the cache.
+func (s *ShardedCache) Get(key Key) (value interface{}, ok bool) {
+	return s.shardFor(key).Get(key)
+}
+
+// Remove removes the provided key from the cache.
+func (s *ShardedCache) Remove(key Key) {
+	s.shardFor(key).Remove(key)
+}
+
+// Purge is used to completely clear the cache.
+func (s *ShardedCache) Purge() {
+	for _, c := range s.shards {
+		c.Purge()
+	}
+}
+
+// Keys returns a slice of the keys in the cache, across all shards.
+func (s *ShardedCache) Keys() []interface{} {
+	var keys []interface{}
+	for _, c := range s.shards {
+		keys = append(keys, c.Keys()...)
+	}
+	return keys
+}
+
+// Len returns the number of items in the cache, across all shards.
+func (s *ShardedCache) Len() int {
+	n := 0
+	for _, c := range s.shards {
+		n += c.Len()
+	}
+	return n
+}
+
+// SetOnEvicted sets the eviction callback on every shard.
+//
+// This is a deliberate deviation from Cache's API: Cache exposes
+// OnEvicted as a plain field, but ShardedCache fans out to N underlying
+// shards, each with its own Cache.OnEvicted field, so there is no single
+// field to assign to set the callback for the whole cache. SetOnEvicted
+// exists to cover the same capability, not to mirror the field.
+func (s *ShardedCache) SetOnEvicted(onEvicted func(key Key, value interface{})) {
+	for _, c := range s.shards {
+		c.OnEvicted = onEvicted
+	}
+}
+
+// defaultHasher hashes a Key to a uint64, fast-pathing the common key
+// types used for caching (strings, fixed size byte arrays, and integers)
+// and falling back to an FNV-1a hash of the key's default string
+// formatting for anything else.
+func defaultHasher(key Key) uint64 {
+	switch k := key.(type) {
+	case string:
+		return fnvString(k)
+	case int:
+		return uint64(k)
+	case int8:
+		return uint64(k)
+	case int16:
+		return uint64(k)
+	case int32:
+		return uint64(k)
+	case int64:
+		return uint64(k)
+	case uint:
+		return uint64(k)
+	case uint8:
+		return uint64(k)
+	case uint16:
+		return uint64(k)
+	case uint32:
+		return uint64(k)
+	case uint64:
+		return k
+	case [4]byte:
+		return fnvBytes(k[:])
+	case [8]byte:
+		return fnvBytes(k[:])
+	case [16]byte:
+		return fnvBytes(k[:])
+	case [20]byte:
+		return fnvBytes(k[:])
+	case [32]byte:
+		return fnvBytes(k[:])
+	default:
+		return fnvString(fmt.Sprintf("%v", key))
+	}
+}
+
+func fnvString(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+func fnvBytes(b []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(b)
+	return h.Sum64()
+}
@@ -0,0 +1,51 @@
+package lru
+
+import (
+	"strconv"
+	"testing"
+)
+
+// benchmarkShardedParallel measures Add/Get throughput on a ShardedCache
+// with the given shard count under GOMAXPROCS-wide parallelism.
+func benchmarkShardedParallel(b *testing.B, shards int) {
+	c, err := NewSharded(shards, 1024, nil)
+	if err != nil {
+		b.Fatalf("NewSharded: %v", err)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := strconv.Itoa(i % 100000)
+			c.Add(key, i)
+			c.Get(key)
+			i++
+		}
+	})
+}
+
+func BenchmarkShardedCache_Parallel_1Shard(b *testing.B)   { benchmarkShardedParallel(b, 1) }
+func BenchmarkShardedCache_Parallel_4Shards(b *testing.B)  { benchmarkShardedParallel(b, 4) }
+func BenchmarkShardedCache_Parallel_16Shards(b *testing.B) { benchmarkShardedParallel(b, 16) }
+func BenchmarkShardedCache_Parallel_64Shards(b *testing.B) { benchmarkShardedParallel(b, 64) }
+
+// BenchmarkCache_Parallel benchmarks the unsharded Cache under the same
+// workload as a baseline for comparison.
+func BenchmarkCache_Parallel(b *testing.B) {
+	c, err := New(1024)
+	if err != nil {
+		b.Fatalf("New: %v", err)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := strconv.Itoa(i % 100000)
+			c.Add(key, i)
+			c.Get(key)
+			i++
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package lru
+
+import "testing"
+
+func TestShardedCache_AddGetRemove(t *testing.T) {
+	c, err := NewSharded(4, 128, nil)
+	if err != nil {
+		t.Fatalf("NewSharded: %v", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		c.Add(i, i*2)
+	}
+	for i := 0; i < 100; i++ {
+		v, ok := c.Get(i)
+		if !ok || v != i*2 {
+			t.Fatalf("Get(%d) = %v, %v, want %d, true", i, v, ok, i*2)
+		}
+	}
+	if c.Len() != 100 {
+		t.Fatalf("Len() = %d, want 100", c.Len())
+	}
+
+	c.Remove(0)
+	if _, ok := c.Get(0); ok {
+		t.Fatalf("0 should have been removed")
+	}
+	if c.Len() != 99 {
+		t.Fatalf("Len() = %d, want 99", c.Len())
+	}
+
+	c.Purge()
+	if c.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0 after purge", c.Len())
+	}
+}
+
+func TestNewSharded_RequiresPowerOfTwo(t *testing.T) {
+	if _, err := NewSharded(3, 128, nil); err == nil {
+		t.Fatalf("expected error for non-power-of-two shard count")
+	}
+}
+
+func TestShardedCache_SetOnEvicted(t *testing.T) {
+	c, err := NewSharded(2, 1, nil)
+	if err != nil {
+		t.Fatalf("NewSharded: %v", err)
+	}
+
+	evicted := 0
+	c.SetOnEvicted(func(key Key, value interface{}) {
+		evicted++
+	})
+
+	// Eight adds into two shards (sizePerShard=1) put at least four keys
+	// into one shard, guaranteeing evictions regardless of which shard
+	// each key lands in.
+	for i := 0; i < 8; i++ {
+		c.Add(i, i)
+	}
+	if evicted == 0 {
+		t.Fatalf("expected at least one eviction to have been observed")
+	}
+}
+
+func TestDefaultHasher_FastPaths(t *testing.T) {
+	cases := []Key{
+		"hello",
+		42,
+		uint64(42),
+		[4]byte{1, 2, 3, 4},
+		[16]byte{1, 2, 3, 4},
+		struct{ X int }{X: 1},
+	}
+	for _, k := range cases {
+		if defaultHasher(k) != defaultHasher(k) {
+			t.Fatalf("hash of %v not stable across calls", k)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// This package provides a SIEVE cache, a simple eviction policy that has
+// been shown to offer competitive (and often better) hit ratios than LRU
+// on web and DNS workloads at lower CPU cost. See:
+// https://junchengyang.com/publication/nsdi24-SIEVE.pdf
+package sieve
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+var ErrInvalidSize = fmt.Errorf("Must provide a positive size")
+
+// Cache is a thread-safe fixed size SIEVE cache.
+type Cache struct {
+	maxEntries int
+	evictList  *list.List
+	items      map[interface{}]*list.Element
+	hand       *list.Element
+
+	// OnEvicted optionally specificies a callback function to be
+	// executed when an entry is purged from the cache.
+	OnEvicted func(key Key, value interface{})
+
+	lock sync.Mutex
+}
+
+// A Key may be any value that is comparable. See http://golang.org/ref/spec#Comparison_operators
+type Key interface{}
+
+// entry is used to hold a value in the evictList
+type entry struct {
+	key     Key
+	value   interface{}
+	visited bool
+}
+
+// New creates a SIEVE cache of the given size
+func New(size int) (*Cache, error) {
+	if size < 0 {
+		return nil, ErrInvalidSize
+	}
+	c := &Cache{
+		maxEntries: size,
+		evictList:  list.New(),
+		items:      make(map[interface{}]*list.Element, size),
+	}
+	return c, nil
+}
+
+// Purge is used to completely clear the cache
+func (c *Cache) Purge() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.evictList = list.New()
+	c.items = make(map[interface{}]*list.Element, c.maxEntries)
+	c.hand = nil
+}
+
+// Add adds a value to the cache.
+func (c *Cache) Add(key Key, value interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Check for existing item
+	if ent, ok := c.items[key]; ok {
+		ent.Value.(*entry).value = value
+		ent.Value.(*entry).visited = true
+		return
+	}
+
+	// Verify size not exceeded, evicting if necessary, before inserting
+	if c.maxEntries != 0 && c.evictList.Len() >= c.maxEntries {
+		c.evict()
+	}
+
+	// Add new item at the head
+	ent := c.evictList.PushFront(&entry{key: key, value: value})
+	c.items[key] = ent
+}
+
+// Get looks up a key's value from the cache.
+func (c *Cache) Get(key Key) (value interface{}, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if ent, ok := c.items[key]; ok {
+		ent.Value.(*entry).visited = true
+		return ent.Value.(*entry).value, true
+	}
+	return
+}
+
+// Remove removes the provided key from the cache.
+func (c *Cache) Remove(key Key) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if ent, ok := c.items[key]; ok {
+		c.removeElement(ent)
+	}
+}
+
+// Keys returns a slice of the keys in the cache.
+func (c *Cache) Keys() []interface{} {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	keys := make([]interface{}, len(c.items))
+	i := 0
+	for k := range c.items {
+		keys[i] = k
+		i++
+	}
+
+	return keys
+}
+
+// Len returns the number of items in the cache.
+func (c *Cache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.evictList.Len()
+}
+
+// evict runs the SIEVE eviction algorithm, walking backward from the hand
+// and clearing visited bits until it finds an unvisited entry to evict.
+func (c *Cache) evict() {
+	e := c.hand
+	if e == nil {
+		e = c.evictList.Back()
+	}
+
+	for e != nil {
+		c.hand = e
+		ent := e.Value.(*entry)
+		if !ent.visited {
+			c.removeElement(e)
+			return
+		}
+		ent.visited = false
+		e = c.prev(e)
+	}
+}
+
+// prev returns the element preceding e in the evict list, wrapping to the
+// back of the list once the head is passed.
+func (c *Cache) prev(e *list.Element) *list.Element {
+	if p := e.Prev(); p != nil {
+		return p
+	}
+	return c.evictList.Back()
+}
+
+// removeElement is used to remove a given list element from the cache. If
+// the hand currently points at e, it is retargeted to e's predecessor
+// first, since e is about to be detached from the list and can no longer
+// be walked from.
+func (c *Cache) removeElement(e *list.Element) {
+	if c.hand == e {
+		prev := c.prev(e)
+		if prev == e {
+			prev = nil
+		}
+		c.hand = prev
+	}
+	c.evictList.Remove(e)
+	kv := e.Value.(*entry)
+	delete(c.items, kv.key)
+	if c.OnEvicted != nil {
+		c.OnEvicted(kv.key, kv.value)
+	}
+}
@@ -0,0 +1,75 @@
+package sieve
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/axiomzen/golang-lru"
+)
+
+// zipfKeys generates n keys drawn from a Zipf distribution over
+// cardinality distinct values, which approximates the skewed key
+// popularity seen in web and DNS caching workloads.
+func zipfKeys(n int, cardinality uint64) []uint64 {
+	r := rand.New(rand.NewSource(0))
+	z := rand.NewZipf(r, 1.1, 1.0, cardinality-1)
+	keys := make([]uint64, n)
+	for i := range keys {
+		keys[i] = z.Uint64()
+	}
+	return keys
+}
+
+func BenchmarkSieve_HitRate(b *testing.B) {
+	const cardinality = 10000
+	cache, _ := New(cardinality / 10)
+	keys := zipfKeys(b.N, cardinality)
+
+	var hits int
+	b.ResetTimer()
+	for _, k := range keys {
+		if _, ok := cache.Get(k); ok {
+			hits++
+		} else {
+			cache.Add(k, k)
+		}
+	}
+	b.ReportMetric(float64(hits)/float64(b.N), "hit-ratio")
+}
+
+func BenchmarkLRU_HitRate(b *testing.B) {
+	const cardinality = 10000
+	cache, _ := lru.New(cardinality / 10)
+	keys := zipfKeys(b.N, cardinality)
+
+	var hits int
+	b.ResetTimer()
+	for _, k := range keys {
+		if _, ok := cache.Get(k); ok {
+			hits++
+		} else {
+			cache.Add(k, k)
+		}
+	}
+	b.ReportMetric(float64(hits)/float64(b.N), "hit-ratio")
+}
+
+func BenchmarkSieve_Throughput(b *testing.B) {
+	cache, _ := New(8192)
+	keys := zipfKeys(b.N, 65536)
+
+	b.ResetTimer()
+	for _, k := range keys {
+		cache.Add(k, k)
+	}
+}
+
+func BenchmarkLRU_Throughput(b *testing.B) {
+	cache, _ := lru.New(8192)
+	keys := zipfKeys(b.N, 65536)
+
+	b.ResetTimer()
+	for _, k := range keys {
+		cache.Add(k, k)
+	}
+}
@@ -0,0 +1,52 @@
+package sieve
+
+import "testing"
+
+// TestRemoveRetargetsHand reproduces a corruption where Remove of the
+// element currently under the hand left the hand dangling on a detached
+// list element, causing later Adds to skip eviction and OnEvicted to fire
+// for stale entries.
+func TestRemoveRetargetsHand(t *testing.T) {
+	type pair struct {
+		key   Key
+		value interface{}
+	}
+	var evicted []pair
+	c, err := New(2)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	c.OnEvicted = func(key Key, value interface{}) {
+		evicted = append(evicted, pair{key, value})
+	}
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Add("c", 3) // evicts "a", hand lands on its predecessor
+
+	c.Remove("b")
+	c.Add("b", 200)
+	c.Add("d", 4)
+	c.Add("e", 5)
+
+	if got := c.Len(); got != 2 {
+		t.Fatalf("Len() = %d, want 2", got)
+	}
+
+	stale := 0
+	for _, p := range evicted {
+		if p.key == "b" && p.value == 2 {
+			stale++
+		}
+	}
+	if stale > 1 {
+		t.Fatalf("OnEvicted fired for stale \"b\"=2 entry %d times, want at most 1", stale)
+	}
+
+	if v, ok := c.Get("d"); !ok || v != 4 {
+		t.Fatalf("Get(\"d\") = %v, %v, want 4, true", v, ok)
+	}
+	if v, ok := c.Get("e"); !ok || v != 5 {
+		t.Fatalf("Get(\"e\") = %v, %v, want 5, true", v, ok)
+	}
+}
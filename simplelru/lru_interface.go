@@ -0,0 +1,40 @@
+package simplelru
+
+// A Key may be any value that is comparable. See http://golang.org/ref/spec#Comparison_operators
+type Key interface{}
+
+// LRUCache is the interface implemented by the simple LRU cache and is used
+// as the building block for the more advanced eviction policies (2Q, ARC)
+// that live in the parent package.
+type LRUCache interface {
+	// Add adds a value to the cache, returns true if an eviction occurred and
+	// updates the "recently used"-ness of the key.
+	Add(key, value interface{}) bool
+
+	// Get returns key's value from the cache and
+	// updates the "recently used"-ness of the key. #value, isFound
+	Get(key interface{}) (value interface{}, ok bool)
+
+	// Contains checks if a key exists in cache without updating the
+	// recent-ness.
+	Contains(key interface{}) (ok bool)
+
+	// Peek returns key's value without updating the "recently used"-ness
+	// of the key.
+	Peek(key interface{}) (value interface{}, ok bool)
+
+	// Remove removes a key from the cache.
+	Remove(key interface{}) bool
+
+	// RemoveOldest removes the oldest entry from the cache.
+	RemoveOldest() (interface{}, interface{}, bool)
+
+	// Keys returns a slice of the keys in the cache, from oldest to newest.
+	Keys() []interface{}
+
+	// Len returns the number of items in the cache.
+	Len() int
+
+	// Purge clears all cache entries.
+	Purge()
+}
@@ -0,0 +1,163 @@
+package simplelru
+
+import "testing"
+
+func TestLRU(t *testing.T) {
+	evictCount := 0
+	onEvict := func(k Key, v interface{}) {
+		if k != v {
+			t.Fatalf("evict value not equal to key: %v != %v", k, v)
+		}
+		evictCount++
+	}
+
+	l, err := NewLRU(128, onEvict)
+	if err != nil {
+		t.Fatalf("NewLRU: %v", err)
+	}
+
+	for i := 0; i < 256; i++ {
+		l.Add(i, i)
+	}
+	if l.Len() != 128 {
+		t.Fatalf("bad len: %v", l.Len())
+	}
+	if evictCount != 128 {
+		t.Fatalf("bad evict count: %v", evictCount)
+	}
+
+	for i, k := range l.Keys() {
+		if v, ok := l.Get(k); !ok || v != k || v != i+128 {
+			t.Fatalf("bad key: %v", k)
+		}
+	}
+	for i := 0; i < 128; i++ {
+		if _, ok := l.Get(i); ok {
+			t.Fatalf("should be evicted: %v", i)
+		}
+	}
+	for i := 128; i < 256; i++ {
+		if _, ok := l.Get(i); !ok {
+			t.Fatalf("should not be evicted: %v", i)
+		}
+	}
+	for i := 128; i < 192; i++ {
+		if !l.Remove(i) {
+			t.Fatalf("should be contained: %v", i)
+		}
+		if l.Remove(i) {
+			t.Fatalf("should not be contained: %v", i)
+		}
+		if _, ok := l.Get(i); ok {
+			t.Fatalf("should be deleted: %v", i)
+		}
+	}
+
+	l.Get(192) // expect 192 to be last key in l.Keys()
+	for i, k := range l.Keys() {
+		if (i < 63 && k != i+193) || (i == 63 && k != 192) {
+			t.Fatalf("out of order key: %v", k)
+		}
+	}
+
+	l.Purge()
+	if l.Len() != 0 {
+		t.Fatalf("bad len: %v", l.Len())
+	}
+	if _, ok := l.Get(200); ok {
+		t.Fatalf("should contain nothing")
+	}
+}
+
+func TestLRU_Add(t *testing.T) {
+	evictCount := 0
+	onEvict := func(k Key, v interface{}) {
+		evictCount++
+	}
+
+	l, err := NewLRU(1, onEvict)
+	if err != nil {
+		t.Fatalf("NewLRU: %v", err)
+	}
+
+	if l.Add(1, 1) == true || evictCount != 0 {
+		t.Errorf("should not have an eviction")
+	}
+	if l.Add(2, 2) == false || evictCount != 1 {
+		t.Errorf("should have an eviction")
+	}
+}
+
+func TestLRU_Contains(t *testing.T) {
+	l, err := NewLRU(2, nil)
+	if err != nil {
+		t.Fatalf("NewLRU: %v", err)
+	}
+
+	l.Add(1, 1)
+	l.Add(2, 2)
+	if !l.Contains(1) {
+		t.Errorf("1 should be contained")
+	}
+
+	l.Add(3, 3)
+	if l.Contains(1) {
+		t.Errorf("Contains should not have updated recent-ness of 1")
+	}
+}
+
+func TestLRU_Peek(t *testing.T) {
+	l, err := NewLRU(2, nil)
+	if err != nil {
+		t.Fatalf("NewLRU: %v", err)
+	}
+
+	l.Add(1, 1)
+	l.Add(2, 2)
+	if v, ok := l.Peek(1); !ok || v != 1 {
+		t.Errorf("1 should be set to 1: %v, %v", v, ok)
+	}
+
+	l.Add(3, 3)
+	if l.Contains(1) {
+		t.Errorf("Peek should not have updated recent-ness of 1")
+	}
+}
+
+func TestLRU_Resize(t *testing.T) {
+	onEvictCounter := 0
+	onEvict := func(k Key, v interface{}) {
+		onEvictCounter++
+	}
+	l, err := NewLRU(2, onEvict)
+	if err != nil {
+		t.Fatalf("NewLRU: %v", err)
+	}
+
+	// Downsize
+	l.Add(1, 1)
+	l.Add(2, 2)
+	evicted := l.Resize(1)
+	if evicted != 1 {
+		t.Errorf("1 element should have been evicted: %v", evicted)
+	}
+	if onEvictCounter != 1 {
+		t.Errorf("onEvicted should have been called 1 time: %v", onEvictCounter)
+	}
+
+	l.Add(3, 3)
+	if l.Contains(1) {
+		t.Errorf("Element 1 should have been evicted")
+	}
+
+	// Upsize
+	evicted = l.Resize(2)
+	if evicted != 0 {
+		t.Errorf("0 elements should have been evicted: %v", evicted)
+	}
+
+	l.Add(4, 4)
+	if !l.Contains(3) || !l.Contains(4) {
+		t.Errorf("Cache should have contained 2 elements")
+	}
+}